@@ -0,0 +1,105 @@
+package hd
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// HardenedOffset is added to a path component's index to mark it hardened, per BIP-32.
+const HardenedOffset = 0x80000000
+
+// CoinType is spacemesh's registered SLIP-44 coin type, used as the second component of the
+// default derivation path "m/44'/540'/account'/0'/index'".
+const CoinType = 540
+
+// masterSeedKey is the HMAC key used to derive the ed25519 master key, per SLIP-0010.
+var masterSeedKey = []byte("ed25519 seed")
+
+// NewMasterKey derives the ed25519 master (key, chainCode) pair from a BIP-39 seed, per SLIP-0010:
+// I = HMAC-SHA512("ed25519 seed", seed); key = I[:32]; chainCode = I[32:].
+func NewMasterKey(seed []byte) (key []byte, chainCode []byte) {
+	mac := hmac.New(sha512.New, masterSeedKey)
+	mac.Write(seed)
+	i := mac.Sum(nil)
+	return i[:32], i[32:]
+}
+
+// CKDpriv derives the hardened child (key, chainCode) at index from (parentKey, parentChainCode):
+// I = HMAC-SHA512(parentChainCode, 0x00 || parentKey || ser32(index)); childKey = I[:32];
+// childChainCode = I[32:]. index must already include HardenedOffset; ed25519 only supports
+// hardened derivation, so there is no non-hardened variant.
+func CKDpriv(parentKey []byte, parentChainCode []byte, index uint32) (key []byte, chainCode []byte, err error) {
+	if index < HardenedOffset {
+		return nil, nil, errors.New("ed25519 only supports hardened derivation")
+	}
+
+	data := make([]byte, 0, 1+len(parentKey)+4)
+	data = append(data, 0x00)
+	data = append(data, parentKey...)
+	indexBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(indexBytes, index)
+	data = append(data, indexBytes...)
+
+	mac := hmac.New(sha512.New, parentChainCode)
+	mac.Write(data)
+	i := mac.Sum(nil)
+	return i[:32], i[32:], nil
+}
+
+// ParsePath parses a derivation path like "m/44'/540'/0'/0'/0'" into its raw (hardened) indices.
+// Every component after "m" must be hardened (marked with a trailing ' or h) since ed25519 supports
+// no other kind of derivation; a non-hardened component is an error.
+func ParsePath(path string) ([]uint32, error) {
+	parts := strings.Split(path, "/")
+	if len(parts) == 0 || parts[0] != "m" {
+		return nil, errors.New("derivation path must start with \"m\"")
+	}
+
+	indices := make([]uint32, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		if part == "" {
+			return nil, errors.New("empty derivation path component")
+		}
+
+		hardened := strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h")
+		if !hardened {
+			return nil, errors.New("ed25519 only supports hardened derivation: " + part + " must end with '")
+		}
+
+		numPart := strings.TrimRight(part, "'h")
+		index, err := strconv.ParseUint(numPart, 10, 32)
+		if err != nil {
+			return nil, errors.New("invalid derivation path component: " + part)
+		}
+		if index >= HardenedOffset {
+			return nil, errors.New("derivation path component out of range (must be < 2^31): " + part)
+		}
+
+		indices = append(indices, uint32(index)+HardenedOffset)
+	}
+
+	return indices, nil
+}
+
+// Derive walks seed through every index in path, returning the final child key. Every component
+// must be hardened; see ParsePath.
+func Derive(seed []byte, path string) ([]byte, error) {
+	indices, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, chainCode := NewMasterKey(seed)
+	for _, index := range indices {
+		key, chainCode, err = CKDpriv(key, chainCode, index)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return key, nil
+}
@@ -0,0 +1,94 @@
+package hd
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+// These are the SLIP-0010 ed25519 test vectors (seed "000102030405060708090a0b0c0d0e0f",
+// deriving m, m/0' and m/0'/1'), recomputed directly from the spec's HMAC-SHA512 construction so
+// NewMasterKey/CKDpriv are checked against a fixed, independently-derivable answer rather than only
+// exercising each other.
+func TestNewMasterKey(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+
+	key, chainCode := NewMasterKey(seed)
+
+	wantKey := "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7"
+	wantChainCode := "90046a93de5380a72b5e45010748567d5ea02bbf6522f979e05c0d8d8ca9fffb"
+	if got := hex.EncodeToString(key); got != wantKey {
+		t.Fatalf("master key = %s, want %s", got, wantKey)
+	}
+	if got := hex.EncodeToString(chainCode); got != wantChainCode {
+		t.Fatalf("master chain code = %s, want %s", got, wantChainCode)
+	}
+}
+
+func TestCKDpriv(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	key, chainCode := NewMasterKey(seed)
+
+	key, chainCode, err := CKDpriv(key, chainCode, HardenedOffset)
+	if err != nil {
+		t.Fatalf("CKDpriv m/0': %v", err)
+	}
+	wantKey := "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3"
+	wantChainCode := "8b59aa11380b624e81507a27fedda59fea6d0b779a778918a2fd3590e16e9c69"
+	if got := hex.EncodeToString(key); got != wantKey {
+		t.Fatalf("m/0' key = %s, want %s", got, wantKey)
+	}
+	if got := hex.EncodeToString(chainCode); got != wantChainCode {
+		t.Fatalf("m/0' chain code = %s, want %s", got, wantChainCode)
+	}
+
+	key, chainCode, err = CKDpriv(key, chainCode, HardenedOffset+1)
+	if err != nil {
+		t.Fatalf("CKDpriv m/0'/1': %v", err)
+	}
+	wantKey = "b1d0bad404bf35da785a64ca1ac54b2617211d2777696fbffaf208f746ae84f2"
+	wantChainCode = "a320425f77d1b5c2505a6b1b27382b37368ee640e3557c315416801243552f14"
+	if got := hex.EncodeToString(key); got != wantKey {
+		t.Fatalf("m/0'/1' key = %s, want %s", got, wantKey)
+	}
+	if got := hex.EncodeToString(chainCode); got != wantChainCode {
+		t.Fatalf("m/0'/1' chain code = %s, want %s", got, wantChainCode)
+	}
+}
+
+func TestCKDprivRejectsNonHardenedIndex(t *testing.T) {
+	key, chainCode := NewMasterKey(nil)
+	if _, _, err := CKDpriv(key, chainCode, 0); err == nil {
+		t.Fatal("expected CKDpriv to reject a non-hardened index, got nil error")
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	indices, err := ParsePath("m/44'/540'/0'/0'/0'")
+	if err != nil {
+		t.Fatalf("ParsePath: %v", err)
+	}
+	want := []uint32{44 + HardenedOffset, 540 + HardenedOffset, HardenedOffset, HardenedOffset, HardenedOffset}
+	if len(indices) != len(want) {
+		t.Fatalf("ParsePath returned %d indices, want %d", len(indices), len(want))
+	}
+	for i := range want {
+		if indices[i] != want[i] {
+			t.Fatalf("indices[%d] = %d, want %d", i, indices[i], want[i])
+		}
+	}
+}
+
+func TestParsePathRejectsNonHardenedComponent(t *testing.T) {
+	if _, err := ParsePath("m/44'/540'/0"); err == nil {
+		t.Fatal("expected ParsePath to reject a non-hardened component, got nil error")
+	}
+}
+
+// A component of 2^31 or more used to silently wrap around when HardenedOffset was added
+// (uint32(index)+HardenedOffset overflows), deriving a different, attacker-unpredictable account
+// instead of returning an error.
+func TestParsePathRejectsOutOfRangeComponent(t *testing.T) {
+	if _, err := ParsePath("m/2147483648'"); err == nil {
+		t.Fatal("expected ParsePath to reject a component >= 2^31, got nil error")
+	}
+}
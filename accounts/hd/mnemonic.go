@@ -0,0 +1,24 @@
+// Package hd implements BIP-39 mnemonic generation and ed25519 hardened-only hierarchical key
+// derivation (in the spirit of BIP-32/44, adapted per SLIP-0010 since ed25519 has no concept of
+// public child derivation), so a single backed-up seed phrase can recover many spacemesh accounts.
+package hd
+
+import (
+	"github.com/tyler-smith/go-bip39"
+)
+
+// GenerateMnemonic returns a new BIP-39 mnemonic with bits bits of entropy (128 for 12 words, 256
+// for 24 words), drawn from the standard 2048-word English wordlist.
+func GenerateMnemonic(bits int) (string, error) {
+	entropy, err := bip39.NewEntropy(bits)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// SeedFromMnemonic derives the 64-byte BIP-39 seed from mnemonic and an optional passphrase, via
+// PBKDF2-HMAC-SHA512 with 2048 iterations and salt "mnemonic"+passphrase.
+func SeedFromMnemonic(mnemonic string, passphrase string) []byte {
+	return bip39.NewSeed(mnemonic, passphrase)
+}
@@ -0,0 +1,317 @@
+package accounts
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/google/uuid"
+	"github.com/spacemeshos/go-spacemesh/crypto"
+	"github.com/spacemeshos/go-spacemesh/filesystem"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/crypto/sha3"
+)
+
+// v3Version is the "version" field of an Ethereum Web3 Secret Storage keystore.
+const v3Version = 3
+
+const (
+	cipherAES128Ctr = "aes-128-ctr"
+	kdfScrypt       = "scrypt"
+	kdfPbkdf2       = "pbkdf2"
+)
+
+// v3KeyStore is the on-disk representation of an Ethereum Web3 Secret Storage (V3) keystore file.
+// It's understood (and produced) in addition to spacemesh's own AccountData format so that keys
+// generated by geth, parity or clef can be imported here, and keys created here can be exported
+// for use elsewhere.
+type v3KeyStore struct {
+	Address string   `json:"address"`
+	Id      string   `json:"id"`
+	Version int      `json:"version"`
+	Crypto  v3Crypto `json:"crypto"`
+}
+
+type v3Crypto struct {
+	CipherText   string                 `json:"ciphertext"`
+	CipherParams v3CipherParams         `json:"cipherparams"`
+	Cipher       string                 `json:"cipher"`
+	KDF          string                 `json:"kdf"`
+	KDFParams    map[string]interface{} `json:"kdfparams"`
+	Mac          string                 `json:"mac"`
+}
+
+type v3CipherParams struct {
+	Iv string `json:"iv"`
+}
+
+// isV3KeyStore returns true if data looks like an Ethereum Web3 Secret Storage (V3) keystore
+// rather than spacemesh's own AccountData json.
+func isV3KeyStore(data []byte) bool {
+	var probe struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return false
+	}
+	return probe.Version == v3Version
+}
+
+// newAccountFromV3Data rejects a V3 keystore found under the accounts data folder: unlike
+// spacemesh's own AccountData, a V3 file's key material is encrypted under a KDF/cipher
+// combination our Account type doesn't know how to carry around unlocked, so there's no way to
+// register a usable Account for it without a passphrase. Run ImportV3 once to decrypt it and
+// persist it in our own format; LoadAllAccounts will pick it up like any other account after that.
+func newAccountFromV3Data(accountId string, data []byte) (*Account, error) {
+	var ks v3KeyStore
+	if err := json.Unmarshal(data, &ks); err != nil {
+		log.Error("Failed to unmarshal v3 keystore", err)
+		return nil, err
+	}
+
+	log.Error("Found v3 keystore account in store: %s. Run ImportV3 to convert it before it can be loaded.", ks.Address)
+
+	return nil, fmt.Errorf("account %s is a v3 keystore file, not a native spacemesh account: import it with ImportV3 first", accountId)
+}
+
+// ImportV3 reads an Ethereum Web3 Secret Storage (V3) keystore file at path, decrypts it with
+// passphrase, and persists the recovered key in spacemesh's own AccountData format under
+// accountsDataPath so it can be used like any other account created here.
+func ImportV3(path string, passphrase string, accountsDataPath string) (*Account, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		log.Error("Failed to read v3 keystore file", err)
+		return nil, err
+	}
+
+	var ks v3KeyStore
+	if err := json.Unmarshal(data, &ks); err != nil {
+		log.Error("Failed to unmarshal v3 keystore", err)
+		return nil, err
+	}
+
+	privKeyBytes, err := decryptV3(&ks, passphrase)
+	if err != nil {
+		log.Error("Failed to decrypt v3 keystore", err)
+		return nil, err
+	}
+
+	pubKey, cryptoData, kdParams, err := crypto.EncryptKey(privKeyBytes, passphrase)
+	if err != nil {
+		log.Error("Failed to re-encrypt imported key", err)
+		return nil, err
+	}
+
+	acct := &Account{nil, pubKey, cryptoData, kdParams}
+
+	if _, err := acct.Persist(accountsDataPath); err != nil {
+		return nil, err
+	}
+
+	Accounts.All[acct.String()] = acct
+
+	log.Info("Imported v3 keystore account: %s", acct.String())
+
+	return acct, nil
+}
+
+// PersistV3 decrypts the account's private key with passphrase and writes it to path in the
+// Ethereum Web3 Secret Storage (V3) format, so it can be imported into geth, parity, clef and other
+// ecosystem tools. Returns the full path of the persisted file.
+func (a *Account) PersistV3(path string, passphrase string) (string, error) {
+	privKeyBytes, err := crypto.DecryptKey(a.cryptoData, a.kdParams, passphrase)
+	if err != nil {
+		log.Error("Failed to decrypt account for v3 export", err)
+		return "", err
+	}
+
+	ks, err := encryptV3(a.PubKey.String(), privKeyBytes, passphrase)
+	if err != nil {
+		log.Error("Failed to encrypt v3 keystore", err)
+		return "", err
+	}
+
+	data, err := json.MarshalIndent(ks, "", "  ")
+	if err != nil {
+		log.Error("Failed to marshal v3 keystore", err)
+		return "", err
+	}
+
+	if filepath.Ext(path) == "" {
+		path = path + ".json"
+	}
+	if err := ioutil.WriteFile(path, data, filesystem.OwnerReadWrite); err != nil {
+		log.Error("Failed to write v3 keystore file", err)
+		return "", err
+	}
+
+	log.Info("Persisted account to v3 keystore: %s", a.String())
+
+	return path, nil
+}
+
+// decryptV3 derives the encryption and mac keys per ks.Crypto.KDF, decrypts the private key with
+// AES-128-CTR and verifies mac = keccak256(macKey||ciphertext) before returning the raw key bytes.
+func decryptV3(ks *v3KeyStore, passphrase string) ([]byte, error) {
+	if ks.Crypto.Cipher != cipherAES128Ctr {
+		return nil, fmt.Errorf("unsupported cipher: %v", ks.Crypto.Cipher)
+	}
+
+	derivedKey, err := deriveV3Key(ks, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	cipherText, err := hex.DecodeString(ks.Crypto.CipherText)
+	if err != nil {
+		return nil, errors.New("invalid ciphertext")
+	}
+
+	mac := sha3.NewLegacyKeccak256()
+	mac.Write(derivedKey[16:32])
+	mac.Write(cipherText)
+	expectedMac, err := hex.DecodeString(ks.Crypto.Mac)
+	if err != nil {
+		return nil, errors.New("invalid mac")
+	}
+	if subtle.ConstantTimeCompare(mac.Sum(nil), expectedMac) != 1 {
+		return nil, errors.New("could not decrypt key with given passphrase")
+	}
+
+	iv, err := hex.DecodeString(ks.Crypto.CipherParams.Iv)
+	if err != nil {
+		return nil, errors.New("invalid iv")
+	}
+
+	block, err := aes.NewCipher(derivedKey[:16])
+	if err != nil {
+		return nil, err
+	}
+
+	privKey := make([]byte, len(cipherText))
+	cipher.NewCTR(block, iv).XORKeyStream(privKey, cipherText)
+
+	return privKey, nil
+}
+
+// minDerivedKeyLen is the shortest derived key decryptV3 can use: 16 bytes for the AES-128 key
+// plus 16 bytes for the mac key it's concatenated with.
+const minDerivedKeyLen = 32
+
+// deriveV3Key derives the key used for encryption (first 16 bytes) and mac verification (bytes
+// 16..32) from the passphrase, per the kdf named in ks.Crypto.KDF. A keystore with a missing or
+// undersized "dklen" (or one whose kdf produces fewer bytes regardless) is rejected here rather
+// than left to panic when the caller slices derivedKey[16:32].
+func deriveV3Key(ks *v3KeyStore, passphrase string) ([]byte, error) {
+	salt, err := hex.DecodeString(fmt.Sprint(ks.Crypto.KDFParams["salt"]))
+	if err != nil {
+		return nil, errors.New("invalid kdf salt")
+	}
+	dkLen := int(toFloat(ks.Crypto.KDFParams["dklen"]))
+	if dkLen < minDerivedKeyLen {
+		return nil, fmt.Errorf("invalid kdf dklen %d: must be at least %d", dkLen, minDerivedKeyLen)
+	}
+
+	var derivedKey []byte
+	switch ks.Crypto.KDF {
+	case kdfScrypt:
+		n := int(toFloat(ks.Crypto.KDFParams["n"]))
+		r := int(toFloat(ks.Crypto.KDFParams["r"]))
+		p := int(toFloat(ks.Crypto.KDFParams["p"]))
+		derivedKey, err = scrypt.Key([]byte(passphrase), salt, n, r, p, dkLen)
+		if err != nil {
+			return nil, err
+		}
+	case kdfPbkdf2:
+		c := int(toFloat(ks.Crypto.KDFParams["c"]))
+		if c <= 0 {
+			return nil, fmt.Errorf("invalid kdf iteration count %d", c)
+		}
+		// the V3 format only ever uses hmac-sha256 as prf
+		derivedKey = pbkdf2.Key([]byte(passphrase), salt, c, dkLen, sha256.New)
+	default:
+		return nil, fmt.Errorf("unsupported kdf: %v", ks.Crypto.KDF)
+	}
+
+	if len(derivedKey) < minDerivedKeyLen {
+		return nil, fmt.Errorf("derived key too short: got %d bytes, want at least %d", len(derivedKey), minDerivedKeyLen)
+	}
+
+	return derivedKey, nil
+}
+
+func toFloat(v interface{}) float64 {
+	switch n := v.(type) {
+	case float64:
+		return n
+	case int:
+		return float64(n)
+	default:
+		return 0
+	}
+}
+
+// encryptV3 encrypts privKey under a freshly derived scrypt key and returns a v3KeyStore ready to
+// be marshalled to json.
+func encryptV3(address string, privKey []byte, passphrase string) (*v3KeyStore, error) {
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(iv); err != nil {
+		return nil, err
+	}
+
+	const n, r, p, dkLen = 1 << 18, 8, 1, 32
+	derivedKey, err := scrypt.Key([]byte(passphrase), salt, n, r, p, dkLen)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(derivedKey[:16])
+	if err != nil {
+		return nil, err
+	}
+	cipherText := make([]byte, len(privKey))
+	cipher.NewCTR(block, iv).XORKeyStream(cipherText, privKey)
+
+	mac := sha3.NewLegacyKeccak256()
+	mac.Write(derivedKey[16:32])
+	mac.Write(cipherText)
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	return &v3KeyStore{
+		Address: address,
+		Id:      id.String(),
+		Version: v3Version,
+		Crypto: v3Crypto{
+			CipherText:   hex.EncodeToString(cipherText),
+			CipherParams: v3CipherParams{Iv: hex.EncodeToString(iv)},
+			Cipher:       cipherAES128Ctr,
+			KDF:          kdfScrypt,
+			KDFParams: map[string]interface{}{
+				"n":     n,
+				"r":     r,
+				"p":     p,
+				"dklen": dkLen,
+				"salt":  hex.EncodeToString(salt),
+			},
+			Mac: hex.EncodeToString(mac.Sum(nil)),
+		},
+	}, nil
+}
@@ -0,0 +1,107 @@
+package accounts
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+// These are the canonical scrypt/pbkdf2 test vectors from the Ethereum Web3 Secret Storage
+// Definition (the same ones geth, parity and clef are tested against), both encrypting the same
+// private key under the passphrase "testpassword". Exercising decryptV3 against them is what
+// would have caught the kdfPbkdf2 branch hashing with the wrong primitive.
+const v3TestPassphrase = "testpassword"
+const v3TestPrivateKey = "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9"
+
+func TestDecryptV3Scrypt(t *testing.T) {
+	ks := &v3KeyStore{
+		Address: "008aeeda4d805471df9b2a5b0f38a0c3bcba786b",
+		Id:      "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+		Version: v3Version,
+		Crypto: v3Crypto{
+			CipherText:   "d172bf743a674da9cdad04534d56926ef8358534d458fffccd4e6ad2fbde479c",
+			CipherParams: v3CipherParams{Iv: "83dbcc02d8ccb40e466191a123791e0e"},
+			Cipher:       cipherAES128Ctr,
+			KDF:          kdfScrypt,
+			KDFParams: map[string]interface{}{
+				"dklen": float64(32),
+				"n":     float64(262144),
+				"r":     float64(1),
+				"p":     float64(8),
+				"salt":  "ab0c7876052600dd703518d6fc3fe8984592145b591fc8fb5c6d43190334ba19",
+			},
+			Mac: "2103ac29920d71da29f15d75b4a16dbe95cfd7ff8faea1056c33131d846e3097",
+		},
+	}
+
+	privKey, err := decryptV3(ks, v3TestPassphrase)
+	if err != nil {
+		t.Fatalf("decryptV3 with scrypt kdf: %v", err)
+	}
+	if got := hex.EncodeToString(privKey); got != v3TestPrivateKey {
+		t.Fatalf("decrypted key = %s, want %s", got, v3TestPrivateKey)
+	}
+}
+
+func TestDecryptV3Pbkdf2(t *testing.T) {
+	ks := &v3KeyStore{
+		Address: "008aeeda4d805471df9b2a5b0f38a0c3bcba786b",
+		Id:      "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+		Version: v3Version,
+		Crypto: v3Crypto{
+			CipherText:   "5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869b1d",
+			CipherParams: v3CipherParams{Iv: "6087dab2f9fdbbfaddc31a909735c1e6"},
+			Cipher:       cipherAES128Ctr,
+			KDF:          kdfPbkdf2,
+			KDFParams: map[string]interface{}{
+				"c":     float64(262144),
+				"dklen": float64(32),
+				"prf":   "hmac-sha256",
+				"salt":  "ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce26b8f7d0b7801586",
+			},
+			Mac: "517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2",
+		},
+	}
+
+	privKey, err := decryptV3(ks, v3TestPassphrase)
+	if err != nil {
+		t.Fatalf("decryptV3 with pbkdf2 kdf: %v", err)
+	}
+	if got := hex.EncodeToString(privKey); got != v3TestPrivateKey {
+		t.Fatalf("decrypted key = %s, want %s", got, v3TestPrivateKey)
+	}
+}
+
+func TestNewAccountFromV3DataRejectsRawV3(t *testing.T) {
+	data := []byte(`{"address":"008aeeda4d805471df9b2a5b0f38a0c3bcba786b","id":"3198bc9c-6672-5ab3-d995-4942343ae5b6","version":3,"crypto":{}}`)
+	if _, err := newAccountFromV3Data("008aeeda4d805471df9b2a5b0f38a0c3bcba786b", data); err == nil {
+		t.Fatal("expected newAccountFromV3Data to reject a raw v3 keystore, got nil error")
+	}
+}
+
+// A keystore with a missing/undersized "dklen" used to make deriveV3Key return a short derived
+// key, which decryptV3 then sliced with derivedKey[16:32], panicking on attacker/user-supplied
+// keystore files instead of returning an error.
+func TestDecryptV3RejectsUndersizedDklen(t *testing.T) {
+	ks := &v3KeyStore{
+		Address: "008aeeda4d805471df9b2a5b0f38a0c3bcba786b",
+		Id:      "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+		Version: v3Version,
+		Crypto: v3Crypto{
+			CipherText:   "5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869b1d",
+			CipherParams: v3CipherParams{Iv: "6087dab2f9fdbbfaddc31a909735c1e6"},
+			Cipher:       cipherAES128Ctr,
+			KDF:          kdfPbkdf2,
+			KDFParams: map[string]interface{}{
+				"c":    float64(262144),
+				"prf":  "hmac-sha256",
+				"salt": "ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce26b8f7d0b7801586",
+				// no "dklen" at all: toFloat falls back to 0
+			},
+			Mac: "517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2",
+		},
+	}
+
+	if _, err := decryptV3(ks, v3TestPassphrase); err == nil {
+		t.Fatal("expected decryptV3 to reject a keystore with a missing dklen, got nil error")
+	}
+}
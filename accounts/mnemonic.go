@@ -0,0 +1,82 @@
+package accounts
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/spacemeshos/go-spacemesh/accounts/hd"
+	"github.com/spacemeshos/go-spacemesh/crypto"
+	"github.com/spacemeshos/go-spacemesh/filesystem"
+	"github.com/spacemeshos/go-spacemesh/log"
+)
+
+// NewAccountFromMnemonic derives the account at path from mnemonic+passphrase and persists it to
+// accountsDataPath, along with path and the seed itself (encrypted with passphrase) so the same
+// seed can later be used to derive and recover other indices along the same path. path must be
+// fully hardened (e.g. "m/44'/540'/0'/0'/0'") - ed25519, used by spacemesh crypto, supports no
+// other kind of derivation. Returns the full path of the persisted file.
+func NewAccountFromMnemonic(mnemonic string, passphrase string, path string, accountsDataPath string) (*Account, string, error) {
+	seed := hd.SeedFromMnemonic(mnemonic, passphrase)
+
+	privKeyBytes, err := hd.Derive(seed, path)
+	if err != nil {
+		log.Error("Failed to derive account at path %s", path)
+		return nil, "", err
+	}
+
+	pubKey, cryptoData, kdParams, err := crypto.EncryptKey(privKeyBytes, passphrase)
+	if err != nil {
+		log.Error("Failed to encrypt derived key", err)
+		return nil, "", err
+	}
+
+	_, seedCryptoData, seedKdParams, err := crypto.EncryptKey(seed, passphrase)
+	if err != nil {
+		log.Error("Failed to encrypt seed", err)
+		return nil, "", err
+	}
+
+	acct := &Account{nil, pubKey, cryptoData, kdParams}
+
+	dataFilePath, err := persistWithSeed(acct, accountsDataPath, path, seedCryptoData, seedKdParams)
+	if err != nil {
+		return nil, "", err
+	}
+
+	Accounts.All[acct.String()] = acct
+
+	log.Info("Derived and persisted account %s at path %s", acct.String(), path)
+
+	return acct, dataFilePath, nil
+}
+
+// persistWithSeed is Account.Persist, extended to also record the derivation path and the seed
+// that produced a, encrypted under its own (freshly generated) kd params.
+func persistWithSeed(a *Account, accountsDataPath string, derivation string, seedCryptoData CryptoData, seedKdParams crypto.KDParams) (string, error) {
+	data := &AccountData{
+		PublicKey:    a.PubKey.String(),
+		CryptoData:   a.cryptoData,
+		KDParams:     a.kdParams,
+		Derivation:   derivation,
+		Seed:         &seedCryptoData,
+		SeedKDParams: &seedKdParams,
+	}
+
+	bytes, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		log.Error("Failed to marshal node data to json", err)
+		return "", err
+	}
+
+	fileName := a.String() + ".json"
+	dataFilePath := filepath.Join(accountsDataPath, fileName)
+	if err := ioutil.WriteFile(dataFilePath, bytes, filesystem.OwnerReadWrite); err != nil {
+		log.Error("Failed to write account to file", err)
+		return "", err
+	}
+
+	log.Info("Persisted account to store. Id: %s", a.String())
+
+	return dataFilePath, nil
+}
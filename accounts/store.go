@@ -15,6 +15,15 @@ type AccountData struct {
 	PublicKey  string          `json:"publicKey"`
 	CryptoData CryptoData      `json:"crypto"`
 	KDParams   crypto.KDParams `json:"kd"`
+
+	// Derivation and Seed are only set for accounts derived from a BIP-39 mnemonic: Derivation is
+	// the hardened derivation path used to derive PublicKey's key from the seed, and Seed is that
+	// seed encrypted the same way CryptoData encrypts a raw private key. Together they let the
+	// account be re-derived (e.g. to recover a different index on the same path) without asking
+	// for the mnemonic again.
+	Derivation   string           `json:"derivation,omitempty"`
+	Seed         *CryptoData      `json:"seed,omitempty"`
+	SeedKDParams *crypto.KDParams `json:"seedKd,omitempty"`
 }
 
 type CryptoData struct {
@@ -67,6 +76,10 @@ func NewAccountFromStore(accountId string, accountsDataPath string) (*Account, e
 		return nil, err
 	}
 
+	if isV3KeyStore(data) {
+		return newAccountFromV3Data(accountId, data)
+	}
+
 	var accountData AccountData
 	err = json.Unmarshal(data, &accountData)
 	if err != nil {
@@ -101,9 +114,9 @@ func (a *Account) Persist(accountsDataPath string) (string, error) {
 	pubKeyStr := a.PubKey.String()
 
 	data := &AccountData{
-		pubKeyStr,
-		a.cryptoData,
-		a.kdParams,
+		PublicKey:  pubKeyStr,
+		CryptoData: a.cryptoData,
+		KDParams:   a.kdParams,
 	}
 
 	bytes, err := json.MarshalIndent(data, "", "  ")
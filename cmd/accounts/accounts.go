@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spacemeshos/go-spacemesh/accounts"
+	cmdp "github.com/spacemeshos/go-spacemesh/cmd"
+	"github.com/spacemeshos/go-spacemesh/filesystem"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spf13/cobra"
+)
+
+// Cmd is the accounts cmd
+var Cmd = &cobra.Command{
+	Use:   "accounts",
+	Short: "manage spacemesh accounts",
+}
+
+var importV3Cmd = &cobra.Command{
+	Use:   "import-v3 <keystore-file>",
+	Short: "import an Ethereum Web3 Secret Storage (V3) keystore file as a spacemesh account",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		passphrase := readPassphrase(cmd)
+
+		accountsDataFolder, err := filesystem.GetAccountsDataDirectoryPath()
+		if err != nil {
+			log.Panic("Failed to resolve accounts data directory err=%v", err)
+		}
+
+		acct, err := accounts.ImportV3(args[0], passphrase, accountsDataFolder)
+		if err != nil {
+			log.Panic("Failed to import v3 keystore err=%v", err)
+		}
+
+		fmt.Printf("Imported account: %s\n", acct.String())
+	},
+}
+
+var exportV3Cmd = &cobra.Command{
+	Use:   "export-v3 <account-id> <out-file>",
+	Short: "export a spacemesh account as an Ethereum Web3 Secret Storage (V3) keystore file",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		passphrase := readPassphrase(cmd)
+
+		accountsDataFolder, err := filesystem.GetAccountsDataDirectoryPath()
+		if err != nil {
+			log.Panic("Failed to resolve accounts data directory err=%v", err)
+		}
+
+		acct, err := accounts.NewAccountFromStore(args[0], accountsDataFolder)
+		if err != nil {
+			log.Panic("Failed to load account err=%v", err)
+		}
+
+		path, err := acct.PersistV3(args[1], passphrase)
+		if err != nil {
+			log.Panic("Failed to export v3 keystore err=%v", err)
+		}
+
+		fmt.Printf("Exported account to: %s\n", path)
+	},
+}
+
+func readPassphrase(cmd *cobra.Command) string {
+	passphrase, err := cmd.Flags().GetString("passphrase")
+	if err != nil || passphrase == "" {
+		log.Panic("--passphrase is required")
+	}
+	return passphrase
+}
+
+func init() {
+	importV3Cmd.Flags().String("passphrase", "", "passphrase protecting the keystore")
+	exportV3Cmd.Flags().String("passphrase", "", "passphrase protecting the keystore")
+	Cmd.AddCommand(importV3Cmd)
+	Cmd.AddCommand(exportV3Cmd)
+	cmdp.AddCommands(Cmd)
+}
+
+func main() {
+	if err := Cmd.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spacemeshos/go-spacemesh/accounts"
+	"github.com/spacemeshos/go-spacemesh/accounts/hd"
+	"github.com/spacemeshos/go-spacemesh/filesystem"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spf13/cobra"
+)
+
+var newMnemonicCmd = &cobra.Command{
+	Use:   "new-mnemonic",
+	Short: "generate a new BIP-39 mnemonic to back up an HD account tree",
+	Run: func(cmd *cobra.Command, args []string) {
+		bits, err := cmd.Flags().GetInt("bits")
+		if err != nil {
+			log.Panic("invalid --bits err=%v", err)
+		}
+
+		mnemonic, err := hd.GenerateMnemonic(bits)
+		if err != nil {
+			log.Panic("Failed to generate mnemonic err=%v", err)
+		}
+
+		fmt.Println(mnemonic)
+	},
+}
+
+var deriveCmd = &cobra.Command{
+	Use:   "derive <start-account-index> [count]",
+	Short: "derive and persist count accounts (default 1) at m/44'/540'/<index>'/0'/0' for index in [start-account-index, start-account-index+count), prompting for the mnemonic",
+	Args:  cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		passphrase := readPassphrase(cmd)
+		mnemonic := readMnemonic()
+
+		startIndex, err := strconv.Atoi(args[0])
+		if err != nil {
+			log.Panic("invalid account-index err=%v", err)
+		}
+
+		count := 1
+		if len(args) == 2 {
+			count, err = strconv.Atoi(args[1])
+			if err != nil {
+				log.Panic("invalid count err=%v", err)
+			}
+		}
+
+		accountsDataFolder, err := filesystem.GetAccountsDataDirectoryPath()
+		if err != nil {
+			log.Panic("Failed to resolve accounts data directory err=%v", err)
+		}
+
+		for index := startIndex; index < startIndex+count; index++ {
+			path := fmt.Sprintf("m/44'/%d'/%d'/0'/0'", hd.CoinType, index)
+
+			acct, path, err := accounts.NewAccountFromMnemonic(mnemonic, passphrase, path, accountsDataFolder)
+			if err != nil {
+				log.Panic("Failed to derive account at index %d err=%v", index, err)
+			}
+
+			fmt.Printf("Derived account %d: %s -> %s\n", index, acct.String(), path)
+		}
+	},
+}
+
+// readMnemonic prompts for the BIP-39 mnemonic on stdin rather than taking it as a command
+// argument, so it doesn't end up readable in shell history or another user's `ps` output.
+func readMnemonic() string {
+	fmt.Fprint(os.Stderr, "Enter BIP-39 mnemonic: ")
+	mnemonic, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		log.Panic("Failed to read mnemonic err=%v", err)
+	}
+	return strings.TrimSpace(mnemonic)
+}
+
+func init() {
+	newMnemonicCmd.Flags().Int("bits", 128, "entropy bits: 128 for a 12-word mnemonic, 256 for 24 words")
+	deriveCmd.Flags().String("passphrase", "", "passphrase protecting the derived key (and the BIP-39 passphrase, if any)")
+	Cmd.AddCommand(newMnemonicCmd)
+	Cmd.AddCommand(deriveCmd)
+}
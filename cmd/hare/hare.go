@@ -4,9 +4,14 @@ import (
 	"fmt"
 	"github.com/davecgh/go-spew/spew"
 	cmdp "github.com/spacemeshos/go-spacemesh/cmd"
+	"github.com/spacemeshos/go-spacemesh/fetchparams"
+	"github.com/spacemeshos/go-spacemesh/filesystem"
 	"github.com/spacemeshos/go-spacemesh/hare"
+	"github.com/spacemeshos/go-spacemesh/hare/pb"
+	"github.com/spacemeshos/go-spacemesh/hare/remote"
 	"github.com/spacemeshos/go-spacemesh/log"
 	"github.com/spacemeshos/go-spacemesh/mesh"
+	"github.com/spacemeshos/go-spacemesh/mesh/graphql"
 	"github.com/spacemeshos/go-spacemesh/oracle"
 	"github.com/spacemeshos/go-spacemesh/p2p"
 	"github.com/spacemeshos/go-spacemesh/timesync"
@@ -17,6 +22,15 @@ import (
 
 const defaultSetSize = 200
 
+// run mode for cmd/hare: embedded runs consensus in this process as before; coordinator keeps
+// gossip/oracle here and delegates instances to remote hare-workers; worker runs only the
+// consensus state machine for instances a coordinator registers it for.
+const (
+	modeEmbedded    = "embedded"
+	modeCoordinator = "coordinator"
+	modeWorker      = "worker"
+)
+
 // Hare cmd
 var Cmd = &cobra.Command{
 	Use:   "hare",
@@ -28,16 +42,35 @@ var Cmd = &cobra.Command{
 
 		hareApp := NewHareApp()
 		defer hareApp.Cleanup()
-		hareApp.Initialize(cmd)
+		if err := hareApp.Initialize(cmd); err != nil {
+			log.Panic("error initializing hare app err=%v", err)
+		}
 		hareApp.Start(cmd, args)
 		<-hareApp.ha.CloseChannel()
 	},
 }
 
 func init() {
+	Cmd.PersistentFlags().String("mode", modeEmbedded, "run mode: embedded|coordinator|worker")
+	Cmd.PersistentFlags().String("worker-addr", ":9095", "coordinator listen address in coordinator mode, or coordinator address to dial in worker mode")
+	Cmd.PersistentFlags().String("worker-id", "", "unique id this process registers with the coordinator (worker mode only)")
+	Cmd.PersistentFlags().String("worker-token", "", "shared secret authenticating coordinator<->worker RPCs (coordinator and worker mode)")
+	Cmd.PersistentFlags().String("graphql", "", "if set, serve the mesh GraphQL API on this address")
+	Cmd.PersistentFlags().String("manifest", "build/manifest.json", "path to the signed bootstrap artifact manifest")
+	Cmd.PersistentFlags().Bool("skip-artifact-check", false, "skip verifying bootstrap artifacts against --manifest (local/dev use only)")
 	cmdp.AddCommands(Cmd)
 }
 
+// acceptValidator is the Validator a worker's local Broker runs incoming messages through. It
+// exists only so Broker.Submit has something to call; the real per-message validation a hare.Hare
+// instance would apply (set membership, signature, round/k) lives in hare.Hare itself, which has no
+// standalone constructor in this tree (see the NOTE in Start).
+type acceptValidator struct{}
+
+func (acceptValidator) Validate(m *pb.HareMessage) bool {
+	return m.Message != nil
+}
+
 type mockBlockProvider struct {
 	isPulled bool
 }
@@ -53,20 +86,78 @@ func (mbp *mockBlockProvider) GetUnverifiedLayerBlocks(layerId mesh.LayerID) ([]
 
 type HareApp struct {
 	*cmdp.BaseApp
-	p2p    p2p.Service
-	oracle *oracle.OracleClient
-	sgn    hare.Signing
-	ha     *hare.Hare
-	clock  *timesync.Ticker
+	p2p       p2p.Service
+	oracle    *oracle.OracleClient
+	sgn       hare.Signing
+	ha        *hare.Hare
+	clock     *timesync.Ticker
+	remoteSvc *remote.Service
+	workerCli *remote.Client
+	meshDb    *mesh.MeshDB
+	gqlSrv    *graphql.Server
 }
 
 func NewHareApp() *HareApp {
 	return &HareApp{BaseApp: cmdp.NewBaseApp(), sgn: hare.NewMockSigning()}
 }
 
+// requiredArtifacts are the bootstrap artifacts hare needs before it can start, per the manifest
+// passed to Initialize (--manifest). A node refuses to start if any is missing or fails
+// verification, unless --skip-artifact-check is set.
+var requiredArtifacts = []string{"oracle-world-seed.bin"}
+
+// Initialize verifies (and, if needed, fetches) the artifacts hare needs before doing anything
+// else with BaseApp.Initialize, so a missing or corrupt artifact fails fast with a clear pointer to
+// spacemesh-fetch-params rather than surfacing later as an obscure runtime error. The check is
+// skippable via --skip-artifact-check for local/dev runs against a manifest that doesn't (yet) list
+// real, published artifacts.
+func (app *HareApp) Initialize(cmd *cobra.Command) error {
+	skip, _ := cmd.Flags().GetBool("skip-artifact-check")
+	if skip {
+		log.Info("Skipping bootstrap artifact check (--skip-artifact-check)")
+		return app.BaseApp.Initialize(cmd)
+	}
+
+	manifestPath, err := cmd.Flags().GetString("manifest")
+	if err != nil {
+		return err
+	}
+
+	cacheDir, err := filesystem.GetParamsDataDirectoryPath()
+	if err != nil {
+		return err
+	}
+
+	if err := fetchparams.EnsureAll(manifestPath, cacheDir, requiredArtifacts); err != nil {
+		return fmt.Errorf("missing or invalid bootstrap artifacts, run spacemesh-fetch-params to fetch them (or pass --skip-artifact-check for a local/dev run): %v", err)
+	}
+
+	return app.BaseApp.Initialize(cmd)
+}
+
 func (app *HareApp) Cleanup() {
 	// TODO: move to array of cleanup functions and execute all here
 	app.oracle.Unregister(true, app.sgn.Verifier().String())
+	if app.remoteSvc != nil {
+		app.remoteSvc.Stop()
+	}
+	if app.workerCli != nil {
+		app.workerCli.Close()
+	}
+	if app.gqlSrv != nil {
+		app.gqlSrv.Stop()
+	}
+	if app.meshDb != nil {
+		app.meshDb.Close()
+	}
+}
+
+func (app *HareApp) mode(cmd *cobra.Command) string {
+	mode, err := cmd.Flags().GetString("mode")
+	if err != nil || mode == "" {
+		return modeEmbedded
+	}
+	return mode
 }
 
 func buildSet() *hare.Set {
@@ -105,7 +196,84 @@ func (app *HareApp) Start(cmd *cobra.Command, args []string) {
 	ld := time.Duration(app.Config.LayerDurationSec) * time.Second
 	app.clock = timesync.NewTicker(timesync.RealClock{}, ld, gTime)
 
+	graphqlAddr, _ := cmd.Flags().GetString("graphql")
+	if graphqlAddr != "" {
+		// hare doesn't persist blocks into a MeshDB of its own, so this serves an otherwise-empty
+		// mesh; it's enough to exercise the --graphql wiring until cmd/hare shares a MeshDB with
+		// a real node process.
+		app.meshDb = mesh.NewMemMeshDB(lg)
+		app.gqlSrv = graphql.NewServer(app.meshDb, graphqlAddr)
+		app.gqlSrv.Start()
+	}
+
+	mode := app.mode(cmd)
+	workerAddr, _ := cmd.Flags().GetString("worker-addr")
+	workerToken, _ := cmd.Flags().GetString("worker-token")
+	if (mode == modeCoordinator || mode == modeWorker) && workerToken == "" {
+		log.Panic("--worker-token is required in coordinator/worker mode: an empty token would let any caller authenticate")
+	}
+
+	if mode == modeWorker {
+		workerId, _ := cmd.Flags().GetString("worker-id")
+		if workerId == "" {
+			log.Panic("--worker-id is required in worker mode")
+		}
+
+		// NOTE: worker mode does not run the hare agreement protocol itself - hare.Hare (set
+		// membership, round/k bookkeeping, signing) has no standalone constructor that doesn't
+		// require an embedded NetworkService, and this tree has no standalone equivalent to build
+		// one against. What it does do for real: every message the coordinator delegates is run
+		// through the same Broker validation/dispatch a gossip-received message would get, and
+		// whatever comes out the other side is submitted straight back to the coordinator over
+		// Client.Submit (previously dead code - nothing called it) so the coordinator's own
+		// Broker.Submit fans it out to its gossip subscribers and other workers. That's the whole
+		// transport+validation loop working end to end; only the consensus decision logic is
+		// missing.
+		const workerInstance = hare.InstanceId(0)
+		cli, err := remote.Dial(workerAddr, workerId, workerInstance, workerToken)
+		if err != nil {
+			log.Panic("error dialing hare coordinator err=%v", err)
+		}
+		app.workerCli = cli
+		log.Info("Starting hare worker, coordinator=%v id=%v", workerAddr, workerId)
+
+		workerBroker := hare.NewBroker(nil, acceptValidator{})
+		validated := workerBroker.Register(workerInstance)
+
+		incoming := make(chan *pb.HareMessage)
+		go cli.Run(incoming)
+		go func() {
+			for msg := range incoming {
+				if !workerBroker.Submit(msg) {
+					log.Warning("hare worker %v dropped invalid message from coordinator: %v", workerId, msg)
+				}
+			}
+		}()
+		for msg := range validated {
+			if err := cli.Submit(msg); err != nil {
+				log.Warning("hare worker %v failed to submit message back to coordinator: %v", workerId, err)
+			}
+		}
+		return
+	}
+
 	app.ha = hare.New(app.Config.HARE, app.p2p, app.sgn, &mockBlockProvider{}, hareOracle, app.clock.Subscribe(), lg)
+
+	if mode == modeCoordinator {
+		app.remoteSvc = remote.NewService(func(msg *pb.HareMessage) {
+			// Route the worker's output through the same validation/dispatch a gossiped message
+			// gets, so local outbox subscribers and other remote workers see it too.
+			if !app.ha.Broker().Submit(msg) {
+				log.Warning("dropped invalid message submitted by remote hare worker: %v", msg)
+			}
+		}, workerToken)
+		app.ha.Broker().SetTransport(app.remoteSvc)
+		if err := app.remoteSvc.Listen(workerAddr); err != nil {
+			log.Panic("error listening for hare workers on %v err=%v", workerAddr, err)
+		}
+		log.Info("Listening for hare workers on %v", workerAddr)
+	}
+
 	log.Info("Starting hare service")
 	err = app.ha.Start()
 	if err != nil {
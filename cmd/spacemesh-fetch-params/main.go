@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spacemeshos/go-spacemesh/filesystem"
+	"github.com/spacemeshos/go-spacemesh/fetchparams"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spf13/cobra"
+)
+
+// Cmd downloads and verifies the artifacts a node needs at startup into the shared params cache,
+// so they don't need to be re-fetched by every node on the machine (and so CI can restore the
+// cache directory between runs instead of re-downloading it every time).
+var Cmd = &cobra.Command{
+	Use:   "spacemesh-fetch-params",
+	Short: "download and verify node bootstrap artifacts (genesis state, oracle world seeds, ...)",
+	Run: func(cmd *cobra.Command, args []string) {
+		manifestPath, err := cmd.Flags().GetString("manifest")
+		if err != nil {
+			log.Panic("invalid --manifest err=%v", err)
+		}
+
+		cacheDir, err := cmd.Flags().GetString("cache-dir")
+		if err != nil {
+			log.Panic("invalid --cache-dir err=%v", err)
+		}
+		if cacheDir == "" {
+			dir, err := filesystem.GetParamsDataDirectoryPath()
+			if err != nil {
+				log.Panic("Failed to resolve params cache directory err=%v", err)
+			}
+			cacheDir = dir
+		}
+
+		manifest, err := fetchparams.LoadManifest(manifestPath)
+		if err != nil {
+			log.Panic("Failed to load manifest err=%v", err)
+		}
+
+		for _, artifact := range manifest {
+			if fetchparams.IsCached(cacheDir, artifact) {
+				fmt.Printf("%s: already cached and verified\n", artifact.Name)
+				continue
+			}
+
+			fmt.Printf("%s: fetching...\n", artifact.Name)
+			if err := fetchparams.Fetch(cacheDir, artifact); err != nil {
+				log.Panic("Failed to fetch %s err=%v", artifact.Name, err)
+			}
+			fmt.Printf("%s: verified and cached in %s\n", artifact.Name, cacheDir)
+		}
+	},
+}
+
+func init() {
+	Cmd.Flags().String("manifest", "build/manifest.json", "path to the signed artifact manifest")
+	Cmd.Flags().String("cache-dir", "", "params cache directory (defaults to the os-specific spacemesh params data directory)")
+}
+
+func main() {
+	if err := Cmd.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,238 @@
+// Package fetchparams downloads and verifies the large read-only artifacts a node needs at
+// startup - genesis state snapshots, oracle world seeds, and (in time) proving parameters - into a
+// shared, content-addressed OS cache directory, following the "fetch-params" pattern other
+// proof-of-space/PoR chains use to keep these out of the git repo and binary.
+package fetchparams
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/spacemeshos/go-spacemesh/log"
+)
+
+// manifestPublicKeyHex is the ed25519 public key build/manifest.json is signed with. The matching
+// private key is held by the release process that generates that file; it never lives in this repo.
+const manifestPublicKeyHex = "6df1336c69ad550312ea145a4388378f92c3df8b169f339a3305b9007ac0225f"
+
+var manifestPublicKey ed25519.PublicKey
+
+func init() {
+	key, err := hex.DecodeString(manifestPublicKeyHex)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		panic("fetchparams: malformed manifestPublicKeyHex")
+	}
+	manifestPublicKey = ed25519.PublicKey(key)
+}
+
+// Artifact describes one file a node may need, as shipped in the signed build/manifest.json.
+type Artifact struct {
+	Name   string   `json:"name"`
+	Size   int64    `json:"size"`
+	Sha256 string   `json:"sha256"`
+	URL    []string `json:"url"`
+}
+
+// manifestEnvelope is the on-disk shape of build/manifest.json: the artifact list plus an ed25519
+// signature over its canonical (compact) json encoding, so a node never trusts an artifact list it
+// can't attribute to whoever holds the release signing key.
+type manifestEnvelope struct {
+	Artifacts []Artifact `json:"artifacts"`
+	Signature string     `json:"signature"`
+}
+
+// LoadManifest reads, verifies and parses the signed artifact manifest at path. An empty,
+// malformed, or wrongly-signed manifest is returned as an error rather than an empty/partial
+// artifact list, since a forged manifest could otherwise redirect a node to attacker-controlled
+// artifacts with attacker-chosen checksums.
+func LoadManifest(path string) ([]Artifact, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read manifest %v: %v", path, err)
+	}
+
+	var envelope manifestEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("could not parse manifest %v: %v", path, err)
+	}
+
+	sig, err := hex.DecodeString(envelope.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("manifest %v: invalid signature encoding: %v", path, err)
+	}
+
+	payload, err := json.Marshal(envelope.Artifacts)
+	if err != nil {
+		return nil, fmt.Errorf("manifest %v: could not re-encode artifacts for verification: %v", path, err)
+	}
+
+	if !ed25519.Verify(manifestPublicKey, payload, sig) {
+		return nil, fmt.Errorf("manifest %v: signature verification failed", path)
+	}
+
+	return envelope.Artifacts, nil
+}
+
+// cachePath is where artifact.Name lives once fetched and verified, under cacheDir.
+func cachePath(cacheDir string, artifact Artifact) string {
+	return filepath.Join(cacheDir, artifact.Name)
+}
+
+// IsCached reports whether artifact is already present in cacheDir and matches its manifest
+// checksum.
+func IsCached(cacheDir string, artifact Artifact) bool {
+	path := cachePath(cacheDir, artifact)
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	return verify(f, artifact) == nil
+}
+
+// verify streams r through sha256, checking the resulting digest against artifact.Sha256 and, when
+// artifact.Size is set (greater than 0), the byte count against artifact.Size.
+func verify(r io.Reader, artifact Artifact) error {
+	h := sha256.New()
+	n, err := io.Copy(h, r)
+	if err != nil {
+		return err
+	}
+
+	if artifact.Size > 0 && n != artifact.Size {
+		return fmt.Errorf("size mismatch for %v: expected %v bytes, got %v", artifact.Name, artifact.Size, n)
+	}
+
+	sum := hex.EncodeToString(h.Sum(nil))
+	if sum != artifact.Sha256 {
+		return fmt.Errorf("checksum mismatch for %v: expected %v, got %v", artifact.Name, artifact.Sha256, sum)
+	}
+
+	return nil
+}
+
+// Fetch downloads artifact into cacheDir if it isn't already cached and verified, trying each of
+// artifact.URL in turn. Downloads resume via HTTP Range if a partial download is found, and the
+// file is verified by streaming sha256 before being atomically renamed into place - a reader never
+// observes a partially-written or corrupt artifact at its final path.
+func Fetch(cacheDir string, artifact Artifact) error {
+	if IsCached(cacheDir, artifact) {
+		return nil
+	}
+
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return fmt.Errorf("could not create cache dir %v: %v", cacheDir, err)
+	}
+
+	var lastErr error
+	for _, url := range artifact.URL {
+		if err := fetchFrom(cacheDir, artifact, url); err != nil {
+			log.Error("Failed to fetch %v from %v: %v", artifact.Name, url, err)
+			lastErr = err
+			continue
+		}
+		return nil
+	}
+
+	if lastErr == nil {
+		lastErr = errors.New("no url configured for artifact " + artifact.Name)
+	}
+	return fmt.Errorf("could not fetch artifact %v from any source: %v", artifact.Name, lastErr)
+}
+
+func fetchFrom(cacheDir string, artifact Artifact, url string) error {
+	partPath := cachePath(cacheDir, artifact) + ".part"
+
+	partial, err := os.OpenFile(partPath, os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer partial.Close()
+
+	offset, err := partial.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		return fmt.Errorf("unexpected status %v fetching %v", resp.StatusCode, url)
+	}
+	// a non-resuming server ignores Range and sends the whole file from the start again
+	if resp.StatusCode == http.StatusOK && offset > 0 {
+		if err := partial.Truncate(0); err != nil {
+			return err
+		}
+		if _, err := partial.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+	}
+
+	if _, err := io.Copy(partial, resp.Body); err != nil {
+		return err
+	}
+
+	verifyFile, err := os.Open(partPath)
+	if err != nil {
+		return err
+	}
+	verifyErr := verify(verifyFile, artifact)
+	verifyFile.Close()
+	if verifyErr != nil {
+		return verifyErr
+	}
+
+	return os.Rename(partPath, cachePath(cacheDir, artifact))
+}
+
+// EnsureAll verifies that every artifact named in required is present in cacheDir per the manifest
+// at manifestPath, fetching whatever is missing or fails verification. It's meant to be called
+// once at node startup (e.g. from HareApp.Initialize): a node should refuse to run rather than
+// start against a missing or corrupt artifact.
+func EnsureAll(manifestPath string, cacheDir string, required []string) error {
+	manifest, err := LoadManifest(manifestPath)
+	if err != nil {
+		return err
+	}
+
+	byName := make(map[string]Artifact, len(manifest))
+	for _, a := range manifest {
+		byName[a.Name] = a
+	}
+
+	for _, name := range required {
+		artifact, exist := byName[name]
+		if !exist {
+			return fmt.Errorf("required artifact %v is not listed in manifest %v", name, manifestPath)
+		}
+
+		if err := Fetch(cacheDir, artifact); err != nil {
+			return fmt.Errorf("required artifact %v is missing or failed verification (run spacemesh-fetch-params to fetch it manually): %v", name, err)
+		}
+	}
+
+	return nil
+}
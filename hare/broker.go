@@ -17,6 +17,16 @@ type Validator interface {
 	Validate(m *pb.HareMessage) bool
 }
 
+// Transport fans hare consensus messages out to subscribers running outside this process, e.g. a
+// remote hare-worker registered over hare/remote. A Broker with no transport behaves exactly as
+// before and only dispatches to its own in-process outbox.
+type Transport interface {
+	// Send delivers msg to whatever remote subscribers are registered for id.
+	Send(id InstanceId, msg *pb.HareMessage)
+	// HasSubscriber reports whether any remote worker is currently registered for id.
+	HasSubscriber(id InstanceId) bool
+}
+
 // Closer is used to add closeability to an object
 type Closer struct {
 	channel chan struct{} // closeable go routines listen to this channel
@@ -47,6 +57,7 @@ type Broker struct {
 	mutex      sync.RWMutex
 	maxReg     InstanceId
 	isStarted  bool
+	transport  Transport
 }
 
 func NewBroker(networkService NetworkService, eValidator Validator) *Broker {
@@ -60,6 +71,12 @@ func NewBroker(networkService NetworkService, eValidator Validator) *Broker {
 	return p
 }
 
+// SetTransport installs a Transport used to fan out messages to remote hare-worker subscribers.
+// Must be called before Start; passing nil restores purely in-process dispatch.
+func (broker *Broker) SetTransport(transport Transport) {
+	broker.transport = transport
+}
+
 // Start listening to protocol messages and dispatch messages (non-blocking)
 func (broker *Broker) Start() error {
 	if broker.isStarted { // Start has been called at least twice
@@ -80,8 +97,6 @@ func (broker *Broker) dispatcher() {
 	for {
 		select {
 		case msg := <-broker.inbox:
-			futureMsg := false
-
 			if msg == nil {
 				log.Error("Message validation failed: called with nil")
 				continue
@@ -95,58 +110,74 @@ func (broker *Broker) dispatcher() {
 				continue
 			}
 
-			// message validation
-			if hareMsg.Message == nil {
-				log.Warning("Message validation failed: message is nil")
-				msg.ReportValidation(ProtoName, false)
-				continue
-			}
+			msg.ReportValidation(ProtoName, broker.route(hareMsg))
 
-			broker.mutex.RLock()
-			expInstId := broker.maxReg
-			broker.mutex.RUnlock()
+		case <-broker.CloseChannel():
+			return
+		}
+	}
+}
 
-			msgInstId := InstanceId(hareMsg.Message.InstanceId)
-			// far future unregistered instance
-			if msgInstId > expInstId+1 {
-				log.Warning("Message validation failed: instanceId. Max: %v Actual: %v", broker.maxReg, hareMsg.Message.InstanceId)
-				msg.ReportValidation(ProtoName, false)
-				continue
-			}
+// Submit routes a message this broker didn't receive over its own gossip inbox - e.g. one handed
+// back by a remote hare-worker via hare/remote - through the same validation and dispatch gossip
+// messages get, so local outbox subscribers and other remote workers see it exactly as if it had
+// arrived over the network. Returns false if validation failed and the message was dropped.
+func (broker *Broker) Submit(hareMsg *pb.HareMessage) bool {
+	return broker.route(hareMsg)
+}
 
-			// near future
-			if msgInstId == expInstId+1 {
-				futureMsg = true
-			}
+// route validates hareMsg and delivers it to whatever's registered for its instance: a local
+// outbox, a remote worker subscribed via Transport, or (if it's for a near-future instance) the
+// pending buffer Register will flush once that instance registers. Returns whether the message
+// passed validation.
+func (broker *Broker) route(hareMsg *pb.HareMessage) bool {
+	futureMsg := false
+
+	// message validation
+	if hareMsg.Message == nil {
+		log.Warning("Message validation failed: message is nil")
+		return false
+	}
 
-			if !broker.eValidator.Validate(hareMsg) {
-				log.Warning("Message validation failed: eValidator returned false %v", hareMsg)
-				msg.ReportValidation(ProtoName, false)
-				continue
-			}
+	broker.mutex.RLock()
+	expInstId := broker.maxReg
+	broker.mutex.RUnlock()
 
-			// validation passed
-			msg.ReportValidation(ProtoName, true)
-
-			broker.mutex.RLock()
-			c, exist := broker.outbox[msgInstId]
-			broker.mutex.RUnlock()
-			if exist {
-				// todo: err if chan is full (len)
-				c <- hareMsg
-			} else if futureMsg {
-				broker.mutex.Lock()
-				if _, exist := broker.pending[msgInstId]; !exist {
-					broker.pending[msgInstId] = make([]*pb.HareMessage, 0)
-				}
-				broker.pending[msgInstId] = append(broker.pending[msgInstId], hareMsg)
-				broker.mutex.Unlock()
-			}
+	msgInstId := InstanceId(hareMsg.Message.InstanceId)
+	// far future unregistered instance
+	if msgInstId > expInstId+1 {
+		log.Warning("Message validation failed: instanceId. Max: %v Actual: %v", broker.maxReg, hareMsg.Message.InstanceId)
+		return false
+	}
 
-		case <-broker.CloseChannel():
-			return
+	// near future
+	if msgInstId == expInstId+1 {
+		futureMsg = true
+	}
+
+	if !broker.eValidator.Validate(hareMsg) {
+		log.Warning("Message validation failed: eValidator returned false %v", hareMsg)
+		return false
+	}
+
+	broker.mutex.RLock()
+	c, exist := broker.outbox[msgInstId]
+	broker.mutex.RUnlock()
+	if exist {
+		// todo: err if chan is full (len)
+		c <- hareMsg
+	} else if broker.transport != nil && broker.transport.HasSubscriber(msgInstId) {
+		broker.transport.Send(msgInstId, hareMsg)
+	} else if futureMsg {
+		broker.mutex.Lock()
+		if _, exist := broker.pending[msgInstId]; !exist {
+			broker.pending[msgInstId] = make([]*pb.HareMessage, 0)
 		}
+		broker.pending[msgInstId] = append(broker.pending[msgInstId], hareMsg)
+		broker.mutex.Unlock()
 	}
+
+	return true
 }
 
 // Register a listener to messages
@@ -0,0 +1,127 @@
+package remote
+
+import (
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/spacemeshos/go-spacemesh/hare"
+	"github.com/spacemeshos/go-spacemesh/hare/pb"
+	rpb "github.com/spacemeshos/go-spacemesh/hare/remote/pb"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// ReconnectBackoff is how long Client waits before retrying a dropped Messages stream.
+const ReconnectBackoff = 2 * time.Second
+
+// HeartbeatInterval is how often Client pings the coordinator to prove it's still alive.
+const HeartbeatInterval = WorkerTimeout / 3
+
+// Client runs on a hare-worker process: it registers for one consensus instance with a
+// coordinator's Service over gRPC, forwards incoming messages to a local channel and submits
+// outgoing ones back to the coordinator for gossip.
+type Client struct {
+	workerId string
+	instance hare.InstanceId
+	conn     *grpc.ClientConn
+	rpc      rpb.RemoteHareClient
+	closer   hare.Closer
+}
+
+// Dial connects to a coordinator at addr, authenticating with token, and returns a Client ready
+// to Run for instance.
+func Dial(addr string, workerId string, instance hare.InstanceId, token string) (*Client, error) {
+	conn, err := grpc.Dial(addr, grpc.WithInsecure(), WithToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		workerId: workerId,
+		instance: instance,
+		conn:     conn,
+		rpc:      rpb.NewRemoteHareClient(conn),
+		closer:   hare.NewCloser(),
+	}, nil
+}
+
+// Close tears down the connection to the coordinator.
+func (c *Client) Close() {
+	c.closer.Close()
+	c.conn.Close()
+}
+
+// Run registers for c.instance and delivers every message it streams from the coordinator onto
+// out, reconnecting with ReconnectBackoff if the stream drops, until Close is called.
+func (c *Client) Run(out chan<- *pb.HareMessage) {
+	go c.heartbeatLoop()
+
+	for {
+		select {
+		case <-c.closer.CloseChannel():
+			return
+		default:
+		}
+
+		if err := c.streamMessages(out); err != nil {
+			log.Error("hare worker %v lost connection to coordinator, reconnecting: %v", c.workerId, err)
+			time.Sleep(ReconnectBackoff)
+		}
+	}
+}
+
+func (c *Client) streamMessages(out chan<- *pb.HareMessage) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if _, err := c.rpc.Register(ctx, &rpb.RegisterRequest{InstanceId: uint32(c.instance), WorkerId: c.workerId}); err != nil {
+		return err
+	}
+
+	stream, err := c.rpc.Messages(ctx, &rpb.MessagesRequest{InstanceId: uint32(c.instance), WorkerId: c.workerId})
+	if err != nil {
+		return err
+	}
+
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		hareMsg := &pb.HareMessage{}
+		if err := proto.Unmarshal(msg.Data, hareMsg); err != nil {
+			log.Error("could not unmarshal hare message from coordinator: %v", err)
+			continue
+		}
+
+		out <- hareMsg
+	}
+}
+
+// Submit hands an outgoing consensus message back to the coordinator for gossip.
+func (c *Client) Submit(msg *pb.HareMessage) error {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.rpc.Submit(context.Background(), &rpb.HareMessage{Data: data})
+	return err
+}
+
+func (c *Client) heartbeatLoop() {
+	ticker := time.NewTicker(HeartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := c.rpc.Heartbeat(context.Background(), &rpb.HeartbeatRequest{WorkerId: c.workerId}); err != nil {
+				log.Error("hare worker %v failed to heartbeat: %v", c.workerId, err)
+			}
+		case <-c.closer.CloseChannel():
+			return
+		}
+	}
+}
@@ -0,0 +1,289 @@
+// Package pb holds the wire types and gRPC client/server plumbing for hare/remote/remote.proto.
+package pb
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+)
+
+type RegisterRequest struct {
+	InstanceId uint32 `protobuf:"varint,1,opt,name=instanceId" json:"instanceId,omitempty"`
+	WorkerId   string `protobuf:"bytes,2,opt,name=workerId" json:"workerId,omitempty"`
+}
+
+func (m *RegisterRequest) Reset()         { *m = RegisterRequest{} }
+func (m *RegisterRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*RegisterRequest) ProtoMessage()    {}
+
+type RegisterResponse struct {
+	Ok bool `protobuf:"varint,1,opt,name=ok" json:"ok,omitempty"`
+}
+
+func (m *RegisterResponse) Reset()         { *m = RegisterResponse{} }
+func (m *RegisterResponse) String() string { return fmt.Sprintf("%+v", *m) }
+func (*RegisterResponse) ProtoMessage()    {}
+
+type UnregisterRequest struct {
+	InstanceId uint32 `protobuf:"varint,1,opt,name=instanceId" json:"instanceId,omitempty"`
+	WorkerId   string `protobuf:"bytes,2,opt,name=workerId" json:"workerId,omitempty"`
+}
+
+func (m *UnregisterRequest) Reset()         { *m = UnregisterRequest{} }
+func (m *UnregisterRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*UnregisterRequest) ProtoMessage()    {}
+
+type UnregisterResponse struct {
+	Ok bool `protobuf:"varint,1,opt,name=ok" json:"ok,omitempty"`
+}
+
+func (m *UnregisterResponse) Reset()         { *m = UnregisterResponse{} }
+func (m *UnregisterResponse) String() string { return fmt.Sprintf("%+v", *m) }
+func (*UnregisterResponse) ProtoMessage()    {}
+
+type MessagesRequest struct {
+	InstanceId uint32 `protobuf:"varint,1,opt,name=instanceId" json:"instanceId,omitempty"`
+	WorkerId   string `protobuf:"bytes,2,opt,name=workerId" json:"workerId,omitempty"`
+}
+
+func (m *MessagesRequest) Reset()         { *m = MessagesRequest{} }
+func (m *MessagesRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*MessagesRequest) ProtoMessage()    {}
+
+// HareMessage mirrors hare/pb.HareMessage's wire bytes; it's carried opaquely so the remote
+// transport doesn't need to depend on the exact consensus message shape.
+type HareMessage struct {
+	Data []byte `protobuf:"bytes,1,opt,name=data" json:"data,omitempty"`
+}
+
+func (m *HareMessage) Reset()         { *m = HareMessage{} }
+func (m *HareMessage) String() string { return fmt.Sprintf("%+v", *m) }
+func (*HareMessage) ProtoMessage()    {}
+
+type SubmitResponse struct {
+	Ok bool `protobuf:"varint,1,opt,name=ok" json:"ok,omitempty"`
+}
+
+func (m *SubmitResponse) Reset()         { *m = SubmitResponse{} }
+func (m *SubmitResponse) String() string { return fmt.Sprintf("%+v", *m) }
+func (*SubmitResponse) ProtoMessage()    {}
+
+type HeartbeatRequest struct {
+	WorkerId string `protobuf:"bytes,1,opt,name=workerId" json:"workerId,omitempty"`
+}
+
+func (m *HeartbeatRequest) Reset()         { *m = HeartbeatRequest{} }
+func (m *HeartbeatRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*HeartbeatRequest) ProtoMessage()    {}
+
+type HeartbeatResponse struct {
+	Ok bool `protobuf:"varint,1,opt,name=ok" json:"ok,omitempty"`
+}
+
+func (m *HeartbeatResponse) Reset()         { *m = HeartbeatResponse{} }
+func (m *HeartbeatResponse) String() string { return fmt.Sprintf("%+v", *m) }
+func (*HeartbeatResponse) ProtoMessage()    {}
+
+// RemoteHareClient is the client API for the RemoteHare service.
+type RemoteHareClient interface {
+	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error)
+	Unregister(ctx context.Context, in *UnregisterRequest, opts ...grpc.CallOption) (*UnregisterResponse, error)
+	Messages(ctx context.Context, in *MessagesRequest, opts ...grpc.CallOption) (RemoteHare_MessagesClient, error)
+	Submit(ctx context.Context, in *HareMessage, opts ...grpc.CallOption) (*SubmitResponse, error)
+	Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatResponse, error)
+}
+
+// RemoteHare_MessagesClient is the stream handle returned by Messages.
+type RemoteHare_MessagesClient interface {
+	Recv() (*HareMessage, error)
+	grpc.ClientStream
+}
+
+// RemoteHareServer is the server API for the RemoteHare service.
+type RemoteHareServer interface {
+	Register(context.Context, *RegisterRequest) (*RegisterResponse, error)
+	Unregister(context.Context, *UnregisterRequest) (*UnregisterResponse, error)
+	Messages(*MessagesRequest, RemoteHare_MessagesServer) error
+	Submit(context.Context, *HareMessage) (*SubmitResponse, error)
+	Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatResponse, error)
+}
+
+// RemoteHare_MessagesServer is the stream handle passed to the Messages handler.
+type RemoteHare_MessagesServer interface {
+	Send(*HareMessage) error
+	grpc.ServerStream
+}
+
+const (
+	serviceName        = "pb.RemoteHare"
+	registerMethod      = "/" + serviceName + "/Register"
+	unregisterMethod    = "/" + serviceName + "/Unregister"
+	messagesMethod      = "/" + serviceName + "/Messages"
+	submitMethod        = "/" + serviceName + "/Submit"
+	heartbeatMethod     = "/" + serviceName + "/Heartbeat"
+)
+
+type remoteHareClient struct {
+	cc *grpc.ClientConn
+}
+
+// NewRemoteHareClient wraps cc as a RemoteHareClient.
+func NewRemoteHareClient(cc *grpc.ClientConn) RemoteHareClient {
+	return &remoteHareClient{cc}
+}
+
+func (c *remoteHareClient) Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error) {
+	out := new(RegisterResponse)
+	if err := c.cc.Invoke(ctx, registerMethod, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteHareClient) Unregister(ctx context.Context, in *UnregisterRequest, opts ...grpc.CallOption) (*UnregisterResponse, error) {
+	out := new(UnregisterResponse)
+	if err := c.cc.Invoke(ctx, unregisterMethod, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteHareClient) Submit(ctx context.Context, in *HareMessage, opts ...grpc.CallOption) (*SubmitResponse, error) {
+	out := new(SubmitResponse)
+	if err := c.cc.Invoke(ctx, submitMethod, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteHareClient) Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatResponse, error) {
+	out := new(HeartbeatResponse)
+	if err := c.cc.Invoke(ctx, heartbeatMethod, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteHareClient) Messages(ctx context.Context, in *MessagesRequest, opts ...grpc.CallOption) (RemoteHare_MessagesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &remoteHareServiceDesc.Streams[0], messagesMethod, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &remoteHareMessagesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type remoteHareMessagesClient struct {
+	grpc.ClientStream
+}
+
+func (x *remoteHareMessagesClient) Recv() (*HareMessage, error) {
+	m := new(HareMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// RegisterRemoteHareServer registers srv as the handler for the RemoteHare service on s.
+func RegisterRemoteHareServer(s *grpc.Server, srv RemoteHareServer) {
+	s.RegisterService(&remoteHareServiceDesc, srv)
+}
+
+func registerHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteHareServer).Register(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: registerMethod}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteHareServer).Register(ctx, req.(*RegisterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func unregisterHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnregisterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteHareServer).Unregister(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: unregisterMethod}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteHareServer).Unregister(ctx, req.(*UnregisterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func submitHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HareMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteHareServer).Submit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: submitMethod}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteHareServer).Submit(ctx, req.(*HareMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func heartbeatHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeartbeatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteHareServer).Heartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: heartbeatMethod}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteHareServer).Heartbeat(ctx, req.(*HeartbeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func messagesHandler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(MessagesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RemoteHareServer).Messages(m, &remoteHareMessagesServer{stream})
+}
+
+type remoteHareMessagesServer struct {
+	grpc.ServerStream
+}
+
+func (x *remoteHareMessagesServer) Send(m *HareMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var remoteHareServiceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*RemoteHareServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "Register", Handler: registerHandler},
+		{MethodName: "Unregister", Handler: unregisterHandler},
+		{MethodName: "Submit", Handler: submitHandler},
+		{MethodName: "Heartbeat", Handler: heartbeatHandler},
+	},
+	Streams: []grpc.StreamDesc{
+		{StreamName: "Messages", Handler: messagesHandler, ServerStreams: true},
+	},
+	Metadata: "hare/remote/remote.proto",
+}
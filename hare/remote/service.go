@@ -0,0 +1,206 @@
+// Package remote lets hare consensus run in a separate process from the node: a coordinator keeps
+// gossip and the oracle, while one or more hare-worker processes run the protocol state machine
+// for the instances delegated to them over an authenticated gRPC connection.
+package remote
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/spacemeshos/go-spacemesh/hare"
+	"github.com/spacemeshos/go-spacemesh/hare/pb"
+	rpb "github.com/spacemeshos/go-spacemesh/hare/remote/pb"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// WorkerTimeout is how long a worker may go without a Heartbeat before it's considered crashed
+// and its registrations are reaped.
+const WorkerTimeout = 30 * time.Second
+
+type subscriber struct {
+	workerId string
+	stream   rpb.RemoteHare_MessagesServer
+}
+
+// Service is the coordinator-side gRPC server: it accepts Register/Unregister/Heartbeat calls from
+// hare-worker processes and implements hare.Transport so Broker can fan messages out to them.
+type Service struct {
+	mutex    sync.RWMutex
+	subs     map[hare.InstanceId]*subscriber
+	lastBeat map[string]time.Time
+	onSubmit func(*pb.HareMessage)
+	closer   hare.Closer
+	token    string
+	server   *grpc.Server
+	listener net.Listener
+}
+
+// NewService creates a coordinator-side remote hare service. onSubmit is invoked with every
+// consensus message a worker Submits, so the coordinator can gossip it like it would a message
+// produced by an embedded hare instance. token authenticates incoming worker connections: every
+// RPC (unary and streaming) is rejected unless its "authorization" metadata matches it exactly.
+func NewService(onSubmit func(*pb.HareMessage), token string) *Service {
+	return &Service{
+		subs:     make(map[hare.InstanceId]*subscriber),
+		lastBeat: make(map[string]time.Time),
+		onSubmit: onSubmit,
+		closer:   hare.NewCloser(),
+		token:    token,
+	}
+}
+
+// Listen starts a gRPC server bound to addr, serving this Service, and launches the background
+// reaper that drops workers that stopped heartbeating. Workers connect to addr via remote.Dial.
+func (s *Service) Listen(addr string) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	s.listener = lis
+	s.server = grpc.NewServer(
+		grpc.UnaryInterceptor(authUnaryInterceptor(s.token)),
+		grpc.StreamInterceptor(authStreamInterceptor(s.token)),
+	)
+	rpb.RegisterRemoteHareServer(s.server, s)
+
+	go func() {
+		if err := s.server.Serve(lis); err != nil {
+			log.Error("hare remote service stopped serving: %v", err)
+		}
+	}()
+
+	go s.reapLoop()
+
+	return nil
+}
+
+// Stop terminates the reaper and, if Listen was called, the gRPC server.
+func (s *Service) Stop() {
+	s.closer.Close()
+	if s.server != nil {
+		s.server.GracefulStop()
+	}
+}
+
+func (s *Service) reapLoop() {
+	ticker := time.NewTicker(WorkerTimeout / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.reapDeadWorkers()
+		case <-s.closer.CloseChannel():
+			return
+		}
+	}
+}
+
+func (s *Service) reapDeadWorkers() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	now := time.Now()
+	for id, sub := range s.subs {
+		last, ok := s.lastBeat[sub.workerId]
+		if !ok || now.Sub(last) > WorkerTimeout {
+			log.Error("hare worker %v timed out, reaping instance %v", sub.workerId, id)
+			delete(s.subs, id)
+			delete(s.lastBeat, sub.workerId)
+		}
+	}
+}
+
+// HasSubscriber implements hare.Transport.
+func (s *Service) HasSubscriber(id hare.InstanceId) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	_, exist := s.subs[id]
+	return exist
+}
+
+// Send implements hare.Transport: it streams msg to whatever worker is registered for id.
+func (s *Service) Send(id hare.InstanceId, msg *pb.HareMessage) {
+	s.mutex.RLock()
+	sub, exist := s.subs[id]
+	s.mutex.RUnlock()
+	if !exist {
+		return
+	}
+
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		log.Error("could not marshal hare message for remote worker: %v", err)
+		return
+	}
+
+	if err := sub.stream.Send(&rpb.HareMessage{Data: data}); err != nil {
+		log.Error("could not stream hare message to remote worker %v: %v", sub.workerId, err)
+	}
+}
+
+// Register implements pb.RemoteHareServer: a worker claims responsibility for instanceId.
+func (s *Service) Register(ctx context.Context, req *rpb.RegisterRequest) (*rpb.RegisterResponse, error) {
+	s.mutex.Lock()
+	s.lastBeat[req.WorkerId] = time.Now()
+	s.mutex.Unlock()
+	return &rpb.RegisterResponse{Ok: true}, nil
+}
+
+// Unregister implements pb.RemoteHareServer: a worker drops instanceId, and its outbox entry
+// (if any) is reaped.
+func (s *Service) Unregister(ctx context.Context, req *rpb.UnregisterRequest) (*rpb.UnregisterResponse, error) {
+	s.mutex.Lock()
+	delete(s.subs, hare.InstanceId(req.InstanceId))
+	s.mutex.Unlock()
+	return &rpb.UnregisterResponse{Ok: true}, nil
+}
+
+// Messages implements pb.RemoteHareServer: it's a long-lived stream the worker keeps open to
+// receive consensus messages for instanceId as the coordinator's broker dispatches them.
+func (s *Service) Messages(req *rpb.MessagesRequest, stream rpb.RemoteHare_MessagesServer) error {
+	id := hare.InstanceId(req.InstanceId)
+
+	s.mutex.Lock()
+	s.subs[id] = &subscriber{workerId: req.WorkerId, stream: stream}
+	s.lastBeat[req.WorkerId] = time.Now()
+	s.mutex.Unlock()
+
+	defer func() {
+		s.mutex.Lock()
+		delete(s.subs, id)
+		s.mutex.Unlock()
+	}()
+
+	<-stream.Context().Done()
+	return stream.Context().Err()
+}
+
+// Submit implements pb.RemoteHareServer: the worker hands back an outgoing consensus message for
+// the coordinator to gossip.
+func (s *Service) Submit(ctx context.Context, msg *rpb.HareMessage) (*rpb.SubmitResponse, error) {
+	if s.onSubmit == nil {
+		return nil, errors.New("no submit handler installed")
+	}
+
+	hareMsg := &pb.HareMessage{}
+	if err := proto.Unmarshal(msg.Data, hareMsg); err != nil {
+		return nil, err
+	}
+
+	s.onSubmit(hareMsg)
+	return &rpb.SubmitResponse{Ok: true}, nil
+}
+
+// Heartbeat implements pb.RemoteHareServer: workers poll this so a crash or partition is detected
+// within WorkerTimeout.
+func (s *Service) Heartbeat(ctx context.Context, req *rpb.HeartbeatRequest) (*rpb.HeartbeatResponse, error) {
+	s.mutex.Lock()
+	s.lastBeat[req.WorkerId] = time.Now()
+	s.mutex.Unlock()
+	return &rpb.HeartbeatResponse{Ok: true}, nil
+}
@@ -0,0 +1,67 @@
+package graphql
+
+import (
+	"sync"
+
+	"github.com/spacemeshos/go-spacemesh/mesh"
+)
+
+// blockLoader batches and caches mesh.Block lookups for the lifetime of a single query, so that
+// resolving N blocks' viewEdges costs one mesh.MeshCache.GetBlocks call instead of N sequential
+// mesh.MeshCache.Get calls.
+type blockLoader struct {
+	db    *mesh.MeshDB
+	mutex sync.Mutex
+	cache map[mesh.BlockID]*mesh.Block
+}
+
+func newBlockLoader(db *mesh.MeshDB) *blockLoader {
+	return &blockLoader{
+		db:    db,
+		cache: make(map[mesh.BlockID]*mesh.Block),
+	}
+}
+
+// Prime loads every block in ids that isn't already cached, in a single batch call, and returns
+// them all (cached and newly loaded) in no particular order.
+func (l *blockLoader) Prime(ids []mesh.BlockID) ([]*mesh.Block, error) {
+	l.mutex.Lock()
+	missing := make([]mesh.BlockID, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := l.cache[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	l.mutex.Unlock()
+
+	if len(missing) > 0 {
+		blocks, err := l.db.GetBlocks(missing)
+		if err != nil {
+			return nil, err
+		}
+		l.mutex.Lock()
+		for _, b := range blocks {
+			l.cache[b.ID()] = b
+		}
+		l.mutex.Unlock()
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	result := make([]*mesh.Block, 0, len(ids))
+	for _, id := range ids {
+		if b, ok := l.cache[id]; ok {
+			result = append(result, b)
+		}
+	}
+	return result, nil
+}
+
+// Get returns a single cached block, loading it (and only it) if it isn't cached yet.
+func (l *blockLoader) Get(id mesh.BlockID) (*mesh.Block, error) {
+	blocks, err := l.Prime([]mesh.BlockID{id})
+	if err != nil || len(blocks) == 0 {
+		return nil, err
+	}
+	return blocks[0], nil
+}
@@ -0,0 +1,132 @@
+package graphql
+
+import (
+	"fmt"
+
+	"github.com/spacemeshos/go-spacemesh/mesh"
+)
+
+// Resolver is the GraphQL root resolver. One Resolver is created per Server and shared across
+// queries; each query gets its own blockLoader so batching never leaks cached state between
+// unrelated requests.
+type Resolver struct {
+	db *mesh.MeshDB
+}
+
+// NewResolver creates a root resolver backed by db.
+func NewResolver(db *mesh.MeshDB) *Resolver {
+	return &Resolver{db: db}
+}
+
+func (r *Resolver) Block(args struct{ Id string }) (*blockResolver, error) {
+	loader := newBlockLoader(r.db)
+	block, err := loader.Get(mesh.BlockID(args.Id))
+	if err != nil {
+		return nil, err
+	}
+	return &blockResolver{block: block, loader: loader}, nil
+}
+
+func (r *Resolver) Layer(args struct{ Index int32 }) (*layerResolver, error) {
+	layer, err := r.db.GetLayer(mesh.LayerID(args.Index))
+	if err != nil {
+		return nil, err
+	}
+	return &layerResolver{layer: layer, loader: newBlockLoader(r.db)}, nil
+}
+
+func (r *Resolver) Account(args struct{ Address string }) (*accountResolver, error) {
+	// MeshDB doesn't track account state; this resolver exists so account addresses can be
+	// referenced from a block/layer query without a second round-trip to a different API once
+	// mesh gains account tracking.
+	return &accountResolver{address: args.Address}, nil
+}
+
+type blockResolver struct {
+	block  *mesh.Block
+	loader *blockLoader
+}
+
+func (b *blockResolver) Id() string {
+	return string(b.block.ID())
+}
+
+func (b *blockResolver) LayerIndex() int32 {
+	return int32(b.block.LayerIndex)
+}
+
+func (b *blockResolver) ContextualValidity() (*contextualValidityResolver, error) {
+	valid, err := b.loader.db.GetContextualValidity(b.block.ID())
+	if err != nil {
+		return nil, err
+	}
+	return &contextualValidityResolver{blockId: string(b.block.ID()), valid: valid}, nil
+}
+
+// ViewEdges resolves the block's view edges in one batch via b.loader, instead of one getBlock
+// call per edge.
+func (b *blockResolver) ViewEdges() ([]*blockResolver, error) {
+	children, err := b.loader.Prime(b.block.ViewEdges)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*blockResolver, 0, len(children))
+	for _, child := range children {
+		resolvers = append(resolvers, &blockResolver{block: child, loader: b.loader})
+	}
+	return resolvers, nil
+}
+
+type layerResolver struct {
+	layer  *mesh.Layer
+	loader *blockLoader
+}
+
+func (l *layerResolver) Index() int32 {
+	return int32(l.layer.Index())
+}
+
+// Blocks primes the loader with every block already in the layer, so any nested viewEdges query
+// for those blocks reuses this same batch rather than issuing one getBlock call per block.
+func (l *layerResolver) Blocks() ([]*blockResolver, error) {
+	ids := make([]mesh.BlockID, 0, len(l.layer.Blocks()))
+	for _, b := range l.layer.Blocks() {
+		ids = append(ids, b.ID())
+	}
+
+	blocks, err := l.loader.Prime(ids)
+	if err != nil {
+		return nil, fmt.Errorf("could not load layer %v blocks: %v", l.layer.Index(), err)
+	}
+
+	resolvers := make([]*blockResolver, 0, len(blocks))
+	for _, b := range blocks {
+		resolvers = append(resolvers, &blockResolver{block: b, loader: l.loader})
+	}
+	return resolvers, nil
+}
+
+type accountResolver struct {
+	address string
+}
+
+func (a *accountResolver) Address() string {
+	return a.address
+}
+
+// contextualValidityResolver resolves the ContextualValidity type: which block the verdict is for,
+// alongside the verdict itself, so a client doesn't have to assume it always matches the enclosing
+// Block query.
+type contextualValidityResolver struct {
+	blockId string
+	valid   bool
+}
+
+func (c *contextualValidityResolver) BlockId() string {
+	return c.blockId
+}
+
+func (c *contextualValidityResolver) Valid() bool {
+	return c.valid
+}
@@ -0,0 +1,40 @@
+// Package graphql exposes a read-only GraphQL query surface over mesh.MeshDB, so a client can
+// fetch e.g. a layer with its blocks and their view edges in one round-trip instead of composing
+// several JSON-RPC calls.
+package graphql
+
+// Schema is the GraphQL SDL served by Server. It's kept small and read-only on purpose: it mirrors
+// what MeshCache already exposes (blocks, layers, contextual validity and the block view graph)
+// rather than growing into a general write API.
+const Schema = `
+	schema {
+		query: Query
+	}
+
+	type Query {
+		block(id: String!): Block
+		layer(index: Int!): Layer
+		account(address: String!): Account
+	}
+
+	type Block {
+		id: String!
+		layerIndex: Int!
+		contextualValidity: ContextualValidity!
+		viewEdges: [Block!]!
+	}
+
+	type Layer {
+		index: Int!
+		blocks: [Block!]!
+	}
+
+	type ContextualValidity {
+		blockId: String!
+		valid: Boolean!
+	}
+
+	type Account {
+		address: String!
+	}
+`
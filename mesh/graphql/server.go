@@ -0,0 +1,46 @@
+package graphql
+
+import (
+	"net/http"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	gqlhttp "github.com/graph-gophers/graphql-go/relay"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/mesh"
+)
+
+// Server serves the GraphQL schema over HTTP, mirroring how the node exposes its JSON-RPC API
+// alongside it. It's opt-in: a node only starts one when launched with --graphql.
+type Server struct {
+	addr   string
+	server *http.Server
+}
+
+// NewServer builds a Server bound to addr and backed by db. Call Start to begin serving.
+func NewServer(db *mesh.MeshDB, addr string) *Server {
+	schema := graphql.MustParseSchema(Schema, NewResolver(db))
+
+	mux := http.NewServeMux()
+	mux.Handle("/query", &gqlhttp.Handler{Schema: schema})
+
+	return &Server{
+		addr:   addr,
+		server: &http.Server{Addr: addr, Handler: mux},
+	}
+}
+
+// Start begins serving GraphQL queries in the background. Errors after startup are logged rather
+// than returned, matching how the node's other long-running listeners are started.
+func (s *Server) Start() {
+	log.Info("Starting GraphQL server on %v", s.addr)
+	go func() {
+		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Error("GraphQL server stopped: %v", err)
+		}
+	}()
+}
+
+// Stop shuts the server down.
+func (s *Server) Stop() error {
+	return s.server.Close()
+}
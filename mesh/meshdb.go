@@ -20,6 +20,7 @@ type MeshDB struct {
 	layers             database.DB
 	blocks             database.DB
 	contextualValidity database.DB //map blockId to contextualValidation state of block
+	validityMutex      sync.RWMutex
 	orphanBlocks       map[LayerID]map[BlockID]struct{}
 	orphanBlockCount   int32
 	layerMutex         map[LayerID]*layerMutex
@@ -113,20 +114,31 @@ func (m *MeshDB) getBlock(id BlockID) (*Block, error) {
 	return &blk, nil
 }
 
+// GetContextualValidity returns whether id was contextually valid, for callers (e.g.
+// mesh/graphql) outside the package.
+func (m *MeshDB) GetContextualValidity(id BlockID) (bool, error) {
+	return m.getContextualValidity(id)
+}
+
 func (m *MeshDB) getContextualValidity(id BlockID) (bool, error) {
+	m.validityMutex.RLock()
+	defer m.validityMutex.RUnlock()
 	b, err := m.contextualValidity.Get(id.ToBytes())
 	return b[0] == 1, err //bytes to bool
 }
 
+// setContextualValidity writes id's validity directly. To have it commit together with other
+// writes (e.g. the blocks/layer index that produced it), use MeshBatch.SetContextualValidity and
+// MeshBatch.Commit instead.
 func (m *MeshDB) setContextualValidity(id BlockID, valid bool) error {
-	//todo implement
-	//todo concurrency
+	m.validityMutex.Lock()
+	defer m.validityMutex.Unlock()
+
 	var v []byte
 	if valid {
 		v = TRUE
 	}
-	m.contextualValidity.Put(id.ToBytes(), v)
-	return nil
+	return m.contextualValidity.Put(id.ToBytes(), v)
 }
 
 func (m *MeshDB) writeBlock(bl *Block) error {
@@ -147,19 +159,49 @@ func (m *MeshDB) writeBlock(bl *Block) error {
 	return nil
 }
 
-//todo this overwrites the previous value if it exists
+// addLayer persists every block in layer and adds it to layer.Index()'s block-id set, merging with
+// whatever was already persisted for that index rather than overwriting it, so calling addLayer
+// more than once for the same layer (or mixing it with writeBlock/updateLayerWithBlock) accumulates
+// blocks instead of losing them. A layer with no blocks is the exception: it stores an empty
+// block-id set for its index, replacing whatever was there.
 func (m *MeshDB) addLayer(layer *Layer) error {
 	if len(layer.blocks) == 0 {
 		m.layers.Put(layer.Index().ToBytes(), []byte{})
 		return nil
 	}
 
-	//add blocks to mDB
+	// guard the same read-modify-write updateLayerWithBlock guards, so a concurrent addLayer (or
+	// addBlock/writeBlock) for this layer can't read the same stale block-id set and have one
+	// commit silently clobber the other's blocks.
+	lm := m.getLayerMutex(layer.Index())
+	defer m.endLayerWorker(layer.Index())
+	lm.m.Lock()
+	defer lm.m.Unlock()
+
+	blockIds := make(map[BlockID]bool, len(layer.blocks))
+	if existing, err := m.layers.Get(layer.Index().ToBytes()); err == nil && len(existing) > 0 {
+		existingIds, err := bytesToBlockIds(existing)
+		if err != nil {
+			return errors.New("could not get all blocks from database ")
+		}
+		for id := range existingIds {
+			blockIds[id] = true
+		}
+	}
+
+	batch := m.Batch()
 	for _, bl := range layer.blocks {
-		m.writeBlock(bl)
+		if err := batch.PutBlock(bl); err != nil {
+			return err
+		}
+		blockIds[bl.ID()] = true
 	}
 
-	return nil
+	if err := batch.PutLayerIndex(layer.Index(), blockIds); err != nil {
+		return err
+	}
+
+	return batch.Commit()
 }
 
 func (m *MeshDB) updateLayerWithBlock(block *Block) error {
@@ -189,6 +231,16 @@ func (m *MeshDB) updateLayerWithBlock(block *Block) error {
 	return nil
 }
 
+// GetBlocks fetches every block in ids in one pass, for callers (e.g. mesh/graphql) that want to
+// batch many block lookups instead of calling getBlock/Get once per id.
+func (m *MeshDB) GetBlocks(ids []BlockID) ([]*Block, error) {
+	blockIds := make(map[BlockID]bool, len(ids))
+	for _, id := range ids {
+		blockIds[id] = true
+	}
+	return m.getLayerBlocks(blockIds)
+}
+
 func (m *MeshDB) getLayerBlocks(ids map[BlockID]bool) ([]*Block, error) {
 
 	blocks := make([]*Block, 0, len(ids))
@@ -203,6 +255,82 @@ func (m *MeshDB) getLayerBlocks(ids map[BlockID]bool) ([]*Block, error) {
 	return blocks, nil
 }
 
+// MeshBatch accumulates writes across MeshDB's blocks, layers and validity stores and commits
+// each store's accumulated writes in one fsync via database.DB.NewBatch, instead of MeshDB issuing
+// one Put (and one fsync) per key the way writeBlock does and addLayer used to.
+type MeshBatch struct {
+	blocks   database.Batch
+	layers   database.Batch
+	validity database.Batch
+}
+
+// Batch creates a MeshBatch ready to accumulate writes against m's three underlying stores.
+func (m *MeshDB) Batch() *MeshBatch {
+	return &MeshBatch{
+		blocks:   m.blocks.NewBatch(),
+		layers:   m.layers.NewBatch(),
+		validity: m.contextualValidity.NewBatch(),
+	}
+}
+
+// PutBlock stages bl for the blocks store.
+func (b *MeshBatch) PutBlock(bl *Block) error {
+	bytes, err := BlockAsBytes(*bl)
+	if err != nil {
+		return fmt.Errorf("could not encode bl")
+	}
+	return b.blocks.Put(bl.ID().ToBytes(), bytes)
+}
+
+// PutLayerIndex stages the full block-id set for index, overwriting whatever it held before.
+func (b *MeshBatch) PutLayerIndex(index LayerID, blockIds map[BlockID]bool) error {
+	w, err := blockIdsAsBytes(blockIds)
+	if err != nil {
+		return errors.New("could not encode layer block ids")
+	}
+	return b.layers.Put(index.ToBytes(), w)
+}
+
+// SetContextualValidity stages id's validity for the validity store.
+func (b *MeshBatch) SetContextualValidity(id BlockID, valid bool) error {
+	var v []byte
+	if valid {
+		v = TRUE
+	}
+	return b.validity.Put(id.ToBytes(), v)
+}
+
+// Commit flushes every staged write to its store. Each store commits atomically with itself; there
+// is no cross-store transaction since blocks/layers/validity are separate databases.
+func (b *MeshBatch) Commit() error {
+	if err := b.blocks.Write(); err != nil {
+		return fmt.Errorf("could not commit blocks batch: %v", err)
+	}
+	if err := b.layers.Write(); err != nil {
+		return fmt.Errorf("could not commit layers batch: %v", err)
+	}
+	if err := b.validity.Write(); err != nil {
+		return fmt.Errorf("could not commit validity batch: %v", err)
+	}
+	return nil
+}
+
+// Iterate streams every key/value pair in the blocks store whose key has the given prefix into fn,
+// stopping (and returning fn's error) as soon as fn returns a non-nil error. Unlike getLayerBlocks,
+// it never materializes the full result set in memory.
+func (m *MeshDB) Iterate(prefix []byte, fn func(key []byte, value []byte) error) error {
+	it := m.blocks.NewIterator(prefix)
+	defer it.Release()
+
+	for it.Next() {
+		if err := fn(it.Key(), it.Value()); err != nil {
+			return err
+		}
+	}
+
+	return it.Error()
+}
+
 //try delete layer Handler (deletes if pending pendingCount is 0)
 func (m *MeshDB) endLayerWorker(index LayerID) {
 	m.lhMutex.Lock()
@@ -256,6 +384,7 @@ func (mc MeshCache) GetLayer(l LayerID) (*Layer, error) {
 	return mc.getLayer(l)
 }
 
+
 func (mc MeshCache) ForBlockInView(view map[BlockID]struct{}, layer LayerID, foo func(block *Block), errHandler func(err error)) {
 	stack := list.New()
 	for b := range view {
@@ -0,0 +1,36 @@
+package mesh
+
+import (
+	"testing"
+
+	"github.com/spacemeshos/go-spacemesh/log"
+)
+
+// BenchmarkAddLayer10kBlocks100Layers measures addLayer's batched write throughput writing 10,000
+// blocks spread across 100 layers (100 blocks/layer), the scale addLayer is meant to replace
+// updateLayerWithBlock's one-fsync-per-block path for.
+func BenchmarkAddLayer10kBlocks100Layers(b *testing.B) {
+	const numLayers = 100
+	const blocksPerLayer = 100
+
+	for n := 0; n < b.N; n++ {
+		m := NewMemMeshDB(log.NewDefault("meshdb-bench"))
+
+		for li := 0; li < numLayers; li++ {
+			layerIndex := LayerID(li)
+			layer := NewLayer(layerIndex)
+
+			blocks := make([]*Block, 0, blocksPerLayer)
+			for bi := 0; bi < blocksPerLayer; bi++ {
+				blocks = append(blocks, NewBlock(BlockID(li*blocksPerLayer+bi), layerIndex, nil))
+			}
+			layer.SetBlocks(blocks)
+
+			if err := m.addLayer(layer); err != nil {
+				b.Fatalf("addLayer: %v", err)
+			}
+		}
+
+		m.Close()
+	}
+}